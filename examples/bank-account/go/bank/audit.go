@@ -0,0 +1,156 @@
+package bank
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"sync"
+	"time"
+)
+
+// AuditEvent is a structured record of a single mutating call against
+// BankLibrary, emitted regardless of whether the call succeeded.
+type AuditEvent struct {
+	Timestamp time.Time
+	Actor     string
+	Action    string
+	AccountID string
+	Amount    Money
+	Result    string // "ok" or "error"
+	ErrorCode string // typed error name, e.g. "ErrOverdraft"; empty on success
+}
+
+// AuditSink receives AuditEvents as they are emitted. Implementations
+// must be safe for concurrent use.
+type AuditSink interface {
+	Record(event AuditEvent)
+}
+
+// AuditQuerier is implemented by sinks that can answer Logs queries.
+// MemoryAuditSink implements it; write-only sinks such as
+// FileAuditSink do not.
+type AuditQuerier interface {
+	Query(filter LogFilter) ([]AuditEvent, error)
+}
+
+// LogFilter narrows a Logs query. Zero-valued fields are not applied.
+type LogFilter struct {
+	AccountID string
+	Action    string
+	ErrorCode string
+	Since     time.Time
+	Until     time.Time
+}
+
+func (f LogFilter) matches(e AuditEvent) bool {
+	if f.AccountID != "" && f.AccountID != e.AccountID {
+		return false
+	}
+	if f.Action != "" && f.Action != e.Action {
+		return false
+	}
+	if f.ErrorCode != "" && f.ErrorCode != e.ErrorCode {
+		return false
+	}
+	if !f.Since.IsZero() && e.Timestamp.Before(f.Since) {
+		return false
+	}
+	if !f.Until.IsZero() && e.Timestamp.After(f.Until) {
+		return false
+	}
+	return true
+}
+
+// MemoryAuditSink is an in-memory ring buffer of the most recent
+// capacity events. It is the default sink used by NewBankLibrary.
+type MemoryAuditSink struct {
+	mu       sync.Mutex
+	events   []AuditEvent
+	capacity int
+	next     int
+	full     bool
+}
+
+func NewMemoryAuditSink(capacity int) *MemoryAuditSink {
+	if capacity <= 0 {
+		capacity = 1000
+	}
+	return &MemoryAuditSink{events: make([]AuditEvent, capacity), capacity: capacity}
+}
+
+func (s *MemoryAuditSink) Record(e AuditEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events[s.next] = e
+	s.next = (s.next + 1) % s.capacity
+	if s.next == 0 {
+		s.full = true
+	}
+}
+
+func (s *MemoryAuditSink) Query(filter LogFilter) ([]AuditEvent, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var ordered []AuditEvent
+	if s.full {
+		ordered = append(ordered, s.events[s.next:]...)
+	}
+	ordered = append(ordered, s.events[:s.next]...)
+
+	var out []AuditEvent
+	for _, e := range ordered {
+		if filter.matches(e) {
+			out = append(out, e)
+		}
+	}
+	return out, nil
+}
+
+// FileAuditSink appends each AuditEvent as a line of JSON to w. It does
+// not support querying: a BankLibrary whose only sink is a FileAuditSink
+// returns ErrAuditNotQueryable from Logs.
+type FileAuditSink struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func NewFileAuditSink(w io.Writer) *FileAuditSink {
+	return &FileAuditSink{w: w}
+}
+
+func (s *FileAuditSink) Record(e AuditEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	enc := json.NewEncoder(s.w)
+	_ = enc.Encode(e)
+}
+
+// errorCode maps a BankLibrary error to the stable string used in
+// AuditEvent.ErrorCode and LogFilter.ErrorCode.
+func errorCode(err error) string {
+	switch {
+	case err == nil:
+		return ""
+	case IsErrAccountNotExist(err):
+		return "ErrAccountNotExist"
+	case IsErrNonPositiveAmount(err):
+		return "ErrNonPositiveAmount"
+	case IsErrOverdraft(err):
+		return "ErrOverdraft"
+	case IsErrCurrencyMismatch(err):
+		return "ErrCurrencyMismatch"
+	default:
+		return "ErrUnknown"
+	}
+}
+
+func auditResult(err error) string {
+	if err == nil {
+		return "ok"
+	}
+	return "error"
+}
+
+// ErrAuditNotQueryable is returned by Logs when the configured
+// AuditSink does not implement AuditQuerier.
+var ErrAuditNotQueryable = errors.New("audit sink does not support querying")
@@ -0,0 +1,240 @@
+package bank
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"time"
+)
+
+// --- API Specification ---
+
+type BankAPI interface {
+	Version() string
+	// CreateAccount opens a new account denominated in currency (an
+	// ISO 4217 code such as "USD").
+	CreateAccount(owner, currency string) string
+	Deposit(accountID string, amount Money) error
+	Withdraw(accountID string, amount Money) error
+	Balance(accountID string) (Money, error)
+	Transactions(accountID string) ([]Money, error)
+
+	// TransactionsBetween returns the ledger entries for accountID whose
+	// Timestamp falls within [from, to].
+	TransactionsBetween(accountID string, from, to time.Time) ([]Transaction, error)
+	// TransactionsPage returns a page of the account's ledger, sorted by
+	// sort ("date", "amount", or either prefixed with "-" to reverse),
+	// along with the total number of matching transactions.
+	TransactionsPage(accountID string, page, limit int, sort string) ([]Transaction, int, error)
+	// Transfer atomically moves amount from fromID to toID, recording a
+	// paired TransferOut/TransferIn transaction on each account. If the
+	// two accounts hold different currencies, the credited leg is
+	// converted through the BankLibrary's FXRateProvider.
+	Transfer(fromID, toID string, amount Money, memo string) error
+
+	// Logs returns audit events matching filter, in chronological order.
+	Logs(filter LogFilter) ([]AuditEvent, error)
+
+	// ExportOFX writes an OFX statement download for accountID's
+	// transactions between from and to to w.
+	ExportOFX(accountID string, from, to time.Time, w io.Writer) error
+}
+
+// --- Implementation ---
+
+type Account struct {
+	ID       string
+	Owner    string
+	Currency string
+	Balance  Money
+}
+
+type BankLibrary struct {
+	store         Store
+	audit         AuditSink
+	fx            FXRateProvider
+	nextAccountID int
+}
+
+// NewBankLibrary returns a BankLibrary backed by an in-memory Store, a
+// default MemoryAuditSink, and an FX table with no cross-currency rates
+// configured. Use NewBankLibraryWithStore to persist state, e.g. with
+// SQLiteStore, NewBankLibraryWithAudit to plug in a different AuditSink
+// such as FileAuditSink, or NewBankLibraryWithFX to supply real rates.
+func NewBankLibrary() *BankLibrary {
+	return NewBankLibraryWithStore(NewMemoryStore())
+}
+
+func NewBankLibraryWithStore(store Store) *BankLibrary {
+	return NewBankLibraryWithAudit(store, NewMemoryAuditSink(0))
+}
+
+func NewBankLibraryWithAudit(store Store, audit AuditSink) *BankLibrary {
+	return NewBankLibraryWithFX(store, audit, NewStaticFXRateProvider(nil))
+}
+
+func NewBankLibraryWithFX(store Store, audit AuditSink, fx FXRateProvider) *BankLibrary {
+	return &BankLibrary{store: store, audit: audit, fx: fx}
+}
+
+// record emits an AuditEvent for a mutating call. actor is always
+// "system" for now since BankLibrary has no caller-identity concept yet.
+func (b *BankLibrary) record(action, accountID string, amount Money, err error) {
+	b.audit.Record(AuditEvent{
+		Timestamp: time.Now(),
+		Actor:     "system",
+		Action:    action,
+		AccountID: accountID,
+		Amount:    amount,
+		Result:    auditResult(err),
+		ErrorCode: errorCode(err),
+	})
+}
+
+func (b *BankLibrary) Version() string {
+	return "1"
+}
+
+func (b *BankLibrary) CreateAccount(owner, currency string) string {
+	currency = strings.ToUpper(currency)
+	b.nextAccountID++
+	id := fmt.Sprintf("ACC-%d", b.nextAccountID)
+	err := b.store.CreateAccount(&Account{ID: id, Owner: owner, Currency: currency, Balance: NewMoney(0, currency)})
+	b.record("CreateAccount", id, NewMoney(0, currency), err)
+	return id
+}
+
+func (b *BankLibrary) Deposit(accountID string, amount Money) (err error) {
+	defer func() { b.record("Deposit", accountID, amount, err) }()
+
+	acc, err := b.store.GetAccount(accountID)
+	if err != nil {
+		return err
+	}
+	if amount.Currency != acc.Currency {
+		return ErrCurrencyMismatch{AccountCurrency: acc.Currency, RequestedCurrency: amount.Currency}
+	}
+	if !amount.IsPositive() {
+		return ErrNonPositiveAmount{Amount: amount}
+	}
+	_, err = b.store.Deposit(accountID, amount, time.Now())
+	return err
+}
+
+func (b *BankLibrary) Withdraw(accountID string, amount Money) (err error) {
+	defer func() { b.record("Withdraw", accountID, amount, err) }()
+
+	acc, err := b.store.GetAccount(accountID)
+	if err != nil {
+		return err
+	}
+	if amount.Currency != acc.Currency {
+		return ErrCurrencyMismatch{AccountCurrency: acc.Currency, RequestedCurrency: amount.Currency}
+	}
+	if !amount.IsPositive() {
+		return ErrNonPositiveAmount{Amount: amount}
+	}
+	_, err = b.store.Withdraw(accountID, amount, time.Now())
+	return err
+}
+
+func (b *BankLibrary) Balance(accountID string) (Money, error) {
+	acc, err := b.store.GetAccount(accountID)
+	if err != nil {
+		return Money{}, err
+	}
+	return acc.Balance, nil
+}
+
+func (b *BankLibrary) Transactions(accountID string) ([]Money, error) {
+	txs, err := b.store.ListTransactions(accountID)
+	if err != nil {
+		return nil, err
+	}
+	amounts := make([]Money, len(txs))
+	for i, tx := range txs {
+		switch tx.Kind {
+		case Withdraw, TransferOut:
+			amounts[i] = tx.Amount.Negate()
+		default:
+			amounts[i] = tx.Amount
+		}
+	}
+	return amounts, nil
+}
+
+func (b *BankLibrary) TransactionsBetween(accountID string, from, to time.Time) ([]Transaction, error) {
+	txs, err := b.store.ListTransactions(accountID)
+	if err != nil {
+		return nil, err
+	}
+	var out []Transaction
+	for _, tx := range txs {
+		if !tx.Timestamp.Before(from) && !tx.Timestamp.After(to) {
+			out = append(out, tx)
+		}
+	}
+	return out, nil
+}
+
+func (b *BankLibrary) TransactionsPage(accountID string, page, limit int, sort string) ([]Transaction, int, error) {
+	txs, err := b.store.ListTransactions(accountID)
+	if err != nil {
+		return nil, 0, err
+	}
+	sortTransactions(txs, sort)
+
+	total := len(txs)
+	if page < 1 {
+		page = 1
+	}
+	if limit <= 0 {
+		return nil, total, nil
+	}
+	start := (page - 1) * limit
+	if start >= total {
+		return []Transaction{}, total, nil
+	}
+	end := start + limit
+	if end > total {
+		end = total
+	}
+	return txs[start:end], total, nil
+}
+
+func (b *BankLibrary) Transfer(fromID, toID string, amount Money, memo string) (err error) {
+	defer func() { b.record("Transfer", fromID, amount, err) }()
+
+	from, err := b.store.GetAccount(fromID)
+	if err != nil {
+		return err
+	}
+	to, err := b.store.GetAccount(toID)
+	if err != nil {
+		return err
+	}
+	if amount.Currency != from.Currency {
+		return ErrCurrencyMismatch{AccountCurrency: from.Currency, RequestedCurrency: amount.Currency}
+	}
+
+	credit := amount
+	if to.Currency != from.Currency {
+		rate, rateErr := b.fx.Rate(from.Currency, to.Currency)
+		if rateErr != nil {
+			return rateErr
+		}
+		credit = NewMoney(int64(math.Round(float64(amount.Amount)*rate)), to.Currency)
+	}
+
+	_, _, err = b.store.Transfer(fromID, toID, amount, credit, memo, time.Now())
+	return err
+}
+
+func (b *BankLibrary) Logs(filter LogFilter) ([]AuditEvent, error) {
+	q, ok := b.audit.(AuditQuerier)
+	if !ok {
+		return nil, ErrAuditNotQueryable
+	}
+	return q.Query(filter)
+}
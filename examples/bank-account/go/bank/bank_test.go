@@ -0,0 +1,330 @@
+package bank
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// --- Unit Tests (REQ-005) ---
+
+func TestBankAPI(t *testing.T) {
+	lib := NewBankLibrary()
+	id := lib.CreateAccount("Alice", "USD")
+
+	t.Run("Deposit Positive", func(t *testing.T) {
+		if err := lib.Deposit(id, MustParseMoney("100 USD")); err != nil {
+			t.Errorf("Expected success, got %v", err)
+		}
+	})
+
+	t.Run("CONST-001: Negative Deposit", func(t *testing.T) {
+		err := lib.Deposit(id, MustParseMoney("-50 USD"))
+		if !IsErrNonPositiveAmount(err) {
+			t.Errorf("Expected ErrNonPositiveAmount, got %v", err)
+		}
+		var typed ErrNonPositiveAmount
+		if errors.As(err, &typed) && typed.Amount.Amount != -5000 {
+			t.Errorf("Expected Amount -50.00 USD, got %v", typed.Amount)
+		}
+	})
+
+	t.Run("CONST-002: Overdraft", func(t *testing.T) {
+		err := lib.Withdraw(id, MustParseMoney("200 USD"))
+		if !IsErrOverdraft(err) {
+			t.Errorf("Expected ErrOverdraft, got %v", err)
+		}
+	})
+
+	t.Run("CONST-003: Invalid ID", func(t *testing.T) {
+		_, err := lib.Balance("INVALID")
+		if !IsErrAccountNotExist(err) {
+			t.Errorf("Expected ErrAccountNotExist, got %v", err)
+		}
+		if !errors.Is(err, ErrAccountNotExist{}) {
+			t.Error("Expected errors.Is to match ErrAccountNotExist sentinel")
+		}
+	})
+
+	t.Run("Currency Mismatch", func(t *testing.T) {
+		err := lib.Deposit(id, MustParseMoney("10 EUR"))
+		if !IsErrCurrencyMismatch(err) {
+			t.Errorf("Expected ErrCurrencyMismatch, got %v", err)
+		}
+	})
+}
+
+// TestCreateAccountNormalizesCurrency guards against Account.Currency
+// being stored un-normalized: CreateAccount("Alice", "usd") must behave
+// identically to CreateAccount("Alice", "USD"), since Money.Currency is
+// always upper-cased by NewMoney/ParseMoney.
+func TestCreateAccountNormalizesCurrency(t *testing.T) {
+	lib := NewBankLibrary()
+	id := lib.CreateAccount("Alice", "usd")
+
+	if err := lib.Deposit(id, MustParseMoney("100 USD")); err != nil {
+		t.Errorf("expected deposit in USD to succeed against a lower-cased -currency account, got %v", err)
+	}
+	balance, err := lib.Balance(id)
+	if err != nil {
+		t.Fatalf("Balance failed: %v", err)
+	}
+	if balance != MustParseMoney("100 USD") {
+		t.Errorf("expected balance 100 USD, got %v", balance)
+	}
+}
+
+func TestTransfer(t *testing.T) {
+	lib := NewBankLibrary()
+	alice := lib.CreateAccount("Alice", "USD")
+	bob := lib.CreateAccount("Bob", "USD")
+
+	if err := lib.Deposit(alice, MustParseMoney("100 USD")); err != nil {
+		t.Fatalf("deposit failed: %v", err)
+	}
+
+	if err := lib.Transfer(alice, bob, MustParseMoney("40 USD"), "rent"); err != nil {
+		t.Fatalf("transfer failed: %v", err)
+	}
+
+	aliceBalance, _ := lib.Balance(alice)
+	bobBalance, _ := lib.Balance(bob)
+	if aliceBalance != MustParseMoney("60 USD") {
+		t.Errorf("expected Alice's balance to be 60 USD, got %v", aliceBalance)
+	}
+	if bobBalance != MustParseMoney("40 USD") {
+		t.Errorf("expected Bob's balance to be 40 USD, got %v", bobBalance)
+	}
+
+	txs, err := lib.TransactionsBetween(bob, time.Now().Add(-time.Hour), time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatalf("TransactionsBetween failed: %v", err)
+	}
+	if len(txs) != 1 || txs[0].Kind != TransferIn || txs[0].CounterpartyAccountID != alice {
+		t.Errorf("expected a single TransferIn from %s, got %+v", alice, txs)
+	}
+
+	if err := lib.Transfer(alice, bob, MustParseMoney("1000 USD"), "too much"); !IsErrOverdraft(err) {
+		t.Errorf("expected ErrOverdraft, got %v", err)
+	}
+}
+
+// TestConcurrentDeposits guards against the read-modify-write race in
+// Deposit: 100 concurrent deposits of 100 USD must leave the balance at
+// exactly 10000.00 USD, with no lost updates. Run with -race.
+func TestConcurrentDeposits(t *testing.T) {
+	lib := NewBankLibrary()
+	id := lib.CreateAccount("Alice", "USD")
+
+	const n = 100
+	var wg sync.WaitGroup
+	wg.Add(n)
+	for i := 0; i < n; i++ {
+		go func() {
+			defer wg.Done()
+			if err := lib.Deposit(id, MustParseMoney("100 USD")); err != nil {
+				t.Errorf("deposit failed: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	balance, err := lib.Balance(id)
+	if err != nil {
+		t.Fatalf("Balance failed: %v", err)
+	}
+	if want := MustParseMoney("10000 USD"); balance != want {
+		t.Errorf("expected balance %v after %d concurrent deposits, got %v", want, n, balance)
+	}
+}
+
+func TestTransferCrossCurrency(t *testing.T) {
+	store := NewMemoryStore()
+	audit := NewMemoryAuditSink(0)
+	fx := NewStaticFXRateProvider(map[string]float64{"USD->EUR": 0.5})
+	lib := NewBankLibraryWithFX(store, audit, fx)
+
+	usdAcc := lib.CreateAccount("Alice", "USD")
+	eurAcc := lib.CreateAccount("Bob", "EUR")
+
+	if err := lib.Deposit(usdAcc, MustParseMoney("100 USD")); err != nil {
+		t.Fatalf("deposit failed: %v", err)
+	}
+	if err := lib.Transfer(usdAcc, eurAcc, MustParseMoney("100 USD"), "conversion"); err != nil {
+		t.Fatalf("transfer failed: %v", err)
+	}
+
+	eurBalance, _ := lib.Balance(eurAcc)
+	if eurBalance != MustParseMoney("50 EUR") {
+		t.Errorf("expected Bob's balance to be 50 EUR at the 0.5 rate, got %v", eurBalance)
+	}
+}
+
+// TestTransferCrossCurrencyRounding guards against truncating the
+// converted leg toward zero: 2.99 USD at a 0.5 rate is 149.5 cents,
+// which must round to 150, not truncate to 149.
+func TestTransferCrossCurrencyRounding(t *testing.T) {
+	store := NewMemoryStore()
+	audit := NewMemoryAuditSink(0)
+	fx := NewStaticFXRateProvider(map[string]float64{"USD->EUR": 0.5})
+	lib := NewBankLibraryWithFX(store, audit, fx)
+
+	usdAcc := lib.CreateAccount("Alice", "USD")
+	eurAcc := lib.CreateAccount("Bob", "EUR")
+
+	if err := lib.Deposit(usdAcc, MustParseMoney("2.99 USD")); err != nil {
+		t.Fatalf("deposit failed: %v", err)
+	}
+	if err := lib.Transfer(usdAcc, eurAcc, MustParseMoney("2.99 USD"), "conversion"); err != nil {
+		t.Fatalf("transfer failed: %v", err)
+	}
+
+	eurBalance, _ := lib.Balance(eurAcc)
+	if eurBalance != MustParseMoney("1.50 EUR") {
+		t.Errorf("expected Bob's balance to be rounded to 1.50 EUR, got %v", eurBalance)
+	}
+}
+
+func TestLogs(t *testing.T) {
+	lib := NewBankLibrary()
+	id := lib.CreateAccount("Alice", "USD")
+
+	if err := lib.Deposit(id, MustParseMoney("100 USD")); err != nil {
+		t.Fatalf("deposit failed: %v", err)
+	}
+	if err := lib.Withdraw(id, MustParseMoney("1000 USD")); !IsErrOverdraft(err) {
+		t.Fatalf("expected ErrOverdraft, got %v", err)
+	}
+
+	events, err := lib.Logs(LogFilter{AccountID: id})
+	if err != nil {
+		t.Fatalf("Logs failed: %v", err)
+	}
+	if len(events) != 3 {
+		t.Fatalf("expected 3 events (create, deposit, withdraw), got %d: %+v", len(events), events)
+	}
+	last := events[len(events)-1]
+	if last.Action != "Withdraw" || last.Result != "error" || last.ErrorCode != "ErrOverdraft" {
+		t.Errorf("expected a failed Withdraw event, got %+v", last)
+	}
+
+	failures, err := lib.Logs(LogFilter{AccountID: id, ErrorCode: "ErrOverdraft"})
+	if err != nil {
+		t.Fatalf("Logs failed: %v", err)
+	}
+	if len(failures) != 1 {
+		t.Errorf("expected 1 ErrOverdraft event, got %d", len(failures))
+	}
+}
+
+func TestExportOFX(t *testing.T) {
+	lib := NewBankLibrary()
+	id := lib.CreateAccount("Alice", "USD")
+	if err := lib.Deposit(id, MustParseMoney("100 USD")); err != nil {
+		t.Fatalf("deposit failed: %v", err)
+	}
+	if err := lib.Withdraw(id, MustParseMoney("25 USD")); err != nil {
+		t.Fatalf("withdraw failed: %v", err)
+	}
+
+	var buf strings.Builder
+	from := time.Now().Add(-time.Hour)
+	to := time.Now().Add(time.Hour)
+	if err := lib.ExportOFX(id, from, to, &buf); err != nil {
+		t.Fatalf("ExportOFX failed: %v", err)
+	}
+
+	out := buf.String()
+	for _, want := range []string{"OFXHEADER:100", "<CURDEF>USD</CURDEF>", "<ACCTID>" + id + "</ACCTID>", "<TRNAMT>100.00</TRNAMT>", "<TRNAMT>-25.00</TRNAMT>", "<LEDGERBAL>"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected OFX output to contain %q, got:\n%s", want, out)
+		}
+	}
+
+	if err := lib.ExportOFX("INVALID", from, to, &buf); !IsErrAccountNotExist(err) {
+		t.Errorf("expected ErrAccountNotExist, got %v", err)
+	}
+}
+
+func TestTransactionsPage(t *testing.T) {
+	lib := NewBankLibrary()
+	id := lib.CreateAccount("Alice", "USD")
+	for i := 0; i < 5; i++ {
+		if err := lib.Deposit(id, NewMoney(int64(i+1)*100, "USD")); err != nil {
+			t.Fatalf("deposit failed: %v", err)
+		}
+	}
+
+	page, total, err := lib.TransactionsPage(id, 1, 2, "-amount")
+	if err != nil {
+		t.Fatalf("TransactionsPage failed: %v", err)
+	}
+	if total != 5 {
+		t.Errorf("expected total 5, got %d", total)
+	}
+	if len(page) != 2 || page[0].Amount != MustParseMoney("5 USD") || page[1].Amount != MustParseMoney("4 USD") {
+		t.Errorf("expected descending page [5 4], got %+v", page)
+	}
+}
+
+func TestStateRoundTrip(t *testing.T) {
+	lib := NewBankLibrary()
+	id := lib.CreateAccount("Alice", "USD")
+	if err := lib.Deposit(id, MustParseMoney("100 USD")); err != nil {
+		t.Fatalf("deposit failed: %v", err)
+	}
+
+	state, err := lib.ExportState()
+	if err != nil {
+		t.Fatalf("ExportState failed: %v", err)
+	}
+
+	restored := NewBankLibraryFromState(state, NewMemoryAuditSink(0), NewStaticFXRateProvider(nil))
+	balance, err := restored.Balance(id)
+	if err != nil {
+		t.Fatalf("Balance on restored library failed: %v", err)
+	}
+	if balance != MustParseMoney("100 USD") {
+		t.Errorf("expected restored balance 100 USD, got %v", balance)
+	}
+
+	// A second account created on the restored library must not collide
+	// with IDs that existed before the snapshot.
+	second := restored.CreateAccount("Bob", "USD")
+	if second == id {
+		t.Errorf("expected a fresh account ID, got a collision with %s", id)
+	}
+
+	// The audit trail from before the snapshot (CreateAccount, Deposit)
+	// must have been carried over, not discarded.
+	events, err := restored.Logs(LogFilter{AccountID: id})
+	if err != nil {
+		t.Fatalf("Logs on restored library failed: %v", err)
+	}
+	if len(events) != 2 || events[0].Action != "CreateAccount" || events[1].Action != "Deposit" {
+		t.Errorf("expected restored CreateAccount and Deposit events, got %+v", events)
+	}
+}
+
+func TestParseMoney(t *testing.T) {
+	cases := map[string]Money{
+		"12.34 USD": {Amount: 1234, Currency: "USD"},
+		"12 USD":    {Amount: 1200, Currency: "USD"},
+		"-1.5 EUR":  {Amount: -150, Currency: "EUR"},
+	}
+	for in, want := range cases {
+		got, err := ParseMoney(in)
+		if err != nil {
+			t.Fatalf("ParseMoney(%q) failed: %v", in, err)
+		}
+		if got != want {
+			t.Errorf("ParseMoney(%q) = %+v, want %+v", in, got, want)
+		}
+	}
+
+	if _, err := ParseMoney("not money"); err == nil {
+		t.Error("expected an error for a malformed money string")
+	}
+}
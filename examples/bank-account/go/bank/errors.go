@@ -0,0 +1,103 @@
+package bank
+
+import (
+	"errors"
+	"fmt"
+)
+
+// --- Error Taxonomy ---
+//
+// BankLibrary returns typed errors instead of ad-hoc strings so that
+// callers can distinguish failure modes with errors.Is / errors.As
+// rather than matching on error text.
+
+// ErrAccountNotExist is returned when an operation references an
+// account ID that has no matching Account.
+type ErrAccountNotExist struct {
+	ID string
+}
+
+func (e ErrAccountNotExist) Error() string {
+	return fmt.Sprintf("account %q does not exist", e.ID)
+}
+
+// Is reports whether target is an ErrAccountNotExist, regardless of ID,
+// so callers can use errors.Is(err, ErrAccountNotExist{}).
+func (e ErrAccountNotExist) Is(target error) bool {
+	_, ok := target.(ErrAccountNotExist)
+	return ok
+}
+
+// IsErrAccountNotExist reports whether err is (or wraps) an
+// ErrAccountNotExist.
+func IsErrAccountNotExist(err error) bool {
+	var e ErrAccountNotExist
+	return errors.As(err, &e)
+}
+
+// ErrNonPositiveAmount is returned when a deposit or withdrawal amount
+// is zero or negative.
+type ErrNonPositiveAmount struct {
+	Amount Money
+}
+
+func (e ErrNonPositiveAmount) Error() string {
+	return fmt.Sprintf("amount %s must be positive", e.Amount)
+}
+
+func (e ErrNonPositiveAmount) Is(target error) bool {
+	_, ok := target.(ErrNonPositiveAmount)
+	return ok
+}
+
+// IsErrNonPositiveAmount reports whether err is (or wraps) an
+// ErrNonPositiveAmount.
+func IsErrNonPositiveAmount(err error) bool {
+	var e ErrNonPositiveAmount
+	return errors.As(err, &e)
+}
+
+// ErrOverdraft is returned when a withdrawal would take an account
+// balance below zero.
+type ErrOverdraft struct {
+	Requested Money
+	Balance   Money
+}
+
+func (e ErrOverdraft) Error() string {
+	return fmt.Sprintf("cannot withdraw %s: balance is only %s", e.Requested, e.Balance)
+}
+
+func (e ErrOverdraft) Is(target error) bool {
+	_, ok := target.(ErrOverdraft)
+	return ok
+}
+
+// IsErrOverdraft reports whether err is (or wraps) an ErrOverdraft.
+func IsErrOverdraft(err error) bool {
+	var e ErrOverdraft
+	return errors.As(err, &e)
+}
+
+// ErrCurrencyMismatch is returned when an operation's Money argument is
+// denominated in a currency other than the account's.
+type ErrCurrencyMismatch struct {
+	AccountCurrency   string
+	RequestedCurrency string
+}
+
+func (e ErrCurrencyMismatch) Error() string {
+	return fmt.Sprintf("account is denominated in %s, got %s", e.AccountCurrency, e.RequestedCurrency)
+}
+
+func (e ErrCurrencyMismatch) Is(target error) bool {
+	_, ok := target.(ErrCurrencyMismatch)
+	return ok
+}
+
+// IsErrCurrencyMismatch reports whether err is (or wraps) an
+// ErrCurrencyMismatch.
+func IsErrCurrencyMismatch(err error) bool {
+	var e ErrCurrencyMismatch
+	return errors.As(err, &e)
+}
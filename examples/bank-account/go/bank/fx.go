@@ -0,0 +1,35 @@
+package bank
+
+import "fmt"
+
+// FXRateProvider supplies the multiplier to convert 1 unit of currency
+// `from` into currency `to`. Cross-currency Transfer uses it to compute
+// the credited leg's amount.
+type FXRateProvider interface {
+	Rate(from, to string) (float64, error)
+}
+
+// StaticFXRateProvider is an FXRateProvider backed by a fixed table of
+// rates, keyed "FROM->TO". Same-currency conversions always return 1
+// without consulting the table. If "TO->FROM" is present but "FROM->TO"
+// is not, its reciprocal is used.
+type StaticFXRateProvider struct {
+	rates map[string]float64
+}
+
+func NewStaticFXRateProvider(rates map[string]float64) *StaticFXRateProvider {
+	return &StaticFXRateProvider{rates: rates}
+}
+
+func (p *StaticFXRateProvider) Rate(from, to string) (float64, error) {
+	if from == to {
+		return 1, nil
+	}
+	if r, ok := p.rates[from+"->"+to]; ok {
+		return r, nil
+	}
+	if r, ok := p.rates[to+"->"+from]; ok && r != 0 {
+		return 1 / r, nil
+	}
+	return 0, fmt.Errorf("no FX rate from %s to %s", from, to)
+}
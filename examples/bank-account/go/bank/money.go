@@ -0,0 +1,99 @@
+package bank
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Money represents an exact amount of a single currency, stored as
+// minor units (e.g. cents) to avoid the rounding and off-by-one-cent
+// hazards of using float64 for balances.
+type Money struct {
+	Amount   int64 // minor units, e.g. cents
+	Currency string
+}
+
+func NewMoney(amount int64, currency string) Money {
+	return Money{Amount: amount, Currency: strings.ToUpper(currency)}
+}
+
+// IsPositive reports whether m is greater than zero.
+func (m Money) IsPositive() bool {
+	return m.Amount > 0
+}
+
+// Negate returns -m.
+func (m Money) Negate() Money {
+	return Money{Amount: -m.Amount, Currency: m.Currency}
+}
+
+// Decimal renders the amount as a fixed-point decimal string, e.g.
+// "12.34", without the currency code.
+func (m Money) Decimal() string {
+	neg := ""
+	amt := m.Amount
+	if amt < 0 {
+		neg = "-"
+		amt = -amt
+	}
+	return fmt.Sprintf("%s%d.%02d", neg, amt/100, amt%100)
+}
+
+// String renders m as e.g. "12.34 USD".
+func (m Money) String() string {
+	return fmt.Sprintf("%s %s", m.Decimal(), m.Currency)
+}
+
+// ParseMoney parses a string of the form "<amount> <currency>", e.g.
+// "12.34 USD", assuming two decimal places of precision.
+func ParseMoney(s string) (Money, error) {
+	fields := strings.Fields(s)
+	if len(fields) != 2 {
+		return Money{}, fmt.Errorf(`invalid money %q: want "<amount> <currency>"`, s)
+	}
+	amountStr, currency := fields[0], strings.ToUpper(fields[1])
+
+	neg := false
+	if strings.HasPrefix(amountStr, "-") {
+		neg = true
+		amountStr = amountStr[1:]
+	}
+
+	parts := strings.SplitN(amountStr, ".", 2)
+	whole, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return Money{}, fmt.Errorf("invalid money %q: %w", s, err)
+	}
+
+	frac := "00"
+	if len(parts) == 2 {
+		frac = parts[1]
+		if len(frac) > 2 {
+			return Money{}, fmt.Errorf("invalid money %q: more than 2 decimal places", s)
+		}
+		for len(frac) < 2 {
+			frac += "0"
+		}
+	}
+	fracVal, err := strconv.ParseInt(frac, 10, 64)
+	if err != nil {
+		return Money{}, fmt.Errorf("invalid money %q: %w", s, err)
+	}
+
+	amount := whole*100 + fracVal
+	if neg {
+		amount = -amount
+	}
+	return Money{Amount: amount, Currency: currency}, nil
+}
+
+// MustParseMoney is like ParseMoney but panics on error. Intended for
+// tests and static initialization, not for parsing user input.
+func MustParseMoney(s string) Money {
+	m, err := ParseMoney(s)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
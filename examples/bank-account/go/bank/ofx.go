@@ -0,0 +1,79 @@
+package bank
+
+import (
+	"fmt"
+	"io"
+	"time"
+)
+
+const ofxDateLayout = "20060102150405"
+
+// ExportOFX writes an OFX statement download response (STMTRS) for
+// accountID's transactions between from and to to w, so the account can
+// be imported into desktop finance tools such as GnuCash or Quicken.
+// The output carries the OFX 1.02 SGML header (OFXHEADER:100,
+// VERSION:102), not the OFX 2.x XML header.
+func (b *BankLibrary) ExportOFX(accountID string, from, to time.Time, w io.Writer) error {
+	acc, err := b.store.GetAccount(accountID)
+	if err != nil {
+		return err
+	}
+	txs, err := b.TransactionsBetween(accountID, from, to)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprint(w, "OFXHEADER:100\r\n"+
+		"DATA:OFXSGML\r\n"+
+		"VERSION:102\r\n"+
+		"SECURITY:NONE\r\n"+
+		"ENCODING:USASCII\r\n"+
+		"CHARSET:1252\r\n"+
+		"COMPRESSION:NONE\r\n"+
+		"OLDFILEUID:NONE\r\n"+
+		"NEWFILEUID:NONE\r\n"+
+		"\r\n")
+
+	fmt.Fprint(w, "<OFX>\n<BANKMSGSRSV1>\n<STMTTRNRS>\n"+
+		"<TRNUID>1</TRNUID>\n"+
+		"<STATUS><CODE>0</CODE><SEVERITY>INFO</SEVERITY></STATUS>\n"+
+		"<STMTRS>\n")
+	fmt.Fprintf(w, "<CURDEF>%s</CURDEF>\n<BANKACCTFROM>\n<BANKID>000000000</BANKID>\n", acc.Currency)
+	fmt.Fprintf(w, "<ACCTID>%s</ACCTID>\n<ACCTTYPE>CHECKING</ACCTTYPE>\n</BANKACCTFROM>\n", acc.ID)
+
+	fmt.Fprintf(w, "<BANKTRANLIST>\n<DTSTART>%s</DTSTART>\n<DTEND>%s</DTEND>\n", from.UTC().Format(ofxDateLayout), to.UTC().Format(ofxDateLayout))
+	for _, tx := range txs {
+		fmt.Fprint(w, "<STMTTRN>\n")
+		fmt.Fprintf(w, "<TRNTYPE>%s</TRNTYPE>\n", ofxTrnType(tx.Kind))
+		fmt.Fprintf(w, "<DTPOSTED>%s</DTPOSTED>\n", tx.Timestamp.UTC().Format(ofxDateLayout))
+		fmt.Fprintf(w, "<TRNAMT>%s</TRNAMT>\n", ofxSignedAmount(tx))
+		fmt.Fprintf(w, "<FITID>%s</FITID>\n", tx.ID)
+		if tx.Memo != "" {
+			fmt.Fprintf(w, "<MEMO>%s</MEMO>\n", tx.Memo)
+		}
+		fmt.Fprint(w, "</STMTTRN>\n")
+	}
+	fmt.Fprint(w, "</BANKTRANLIST>\n")
+
+	fmt.Fprintf(w, "<LEDGERBAL>\n<BALAMT>%s</BALAMT>\n<DTASOF>%s</DTASOF>\n</LEDGERBAL>\n", acc.Balance.Decimal(), time.Now().UTC().Format(ofxDateLayout))
+	fmt.Fprint(w, "</STMTRS>\n</STMTTRNRS>\n</BANKMSGSRSV1>\n</OFX>\n")
+	return nil
+}
+
+func ofxTrnType(kind TransactionKind) string {
+	switch kind {
+	case Deposit, TransferIn:
+		return "CREDIT"
+	default:
+		return "DEBIT"
+	}
+}
+
+func ofxSignedAmount(tx Transaction) string {
+	switch tx.Kind {
+	case Withdraw, TransferOut:
+		return tx.Amount.Negate().Decimal()
+	default:
+		return tx.Amount.Decimal()
+	}
+}
@@ -0,0 +1,293 @@
+package bank
+
+import (
+	"database/sql"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// SQLiteStore is a Store backed by a SQLite database, so ledger state
+// survives process restarts. It implements the same Store interface as
+// MemoryStore; BankLibrary does not know or care which one it is using.
+// Money is stored as its minor-unit integer amount alongside its
+// currency code, never as a floating-point column.
+//
+// SQLiteStore also implements AuditSink and AuditQuerier, persisting
+// audit events to the same database so Logs sees history across
+// restarts the way it does for a MemoryStore+MemoryAuditSink pairing
+// restored from State.
+type SQLiteStore struct {
+	db *sql.DB
+}
+
+// NewSQLiteStore opens (and if necessary creates) the schema at path.
+func NewSQLiteStore(path string) (*SQLiteStore, error) {
+	db, err := sql.Open("sqlite3", path)
+	if err != nil {
+		return nil, err
+	}
+	s := &SQLiteStore{db: db}
+	if err := s.migrate(); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *SQLiteStore) Close() error {
+	return s.db.Close()
+}
+
+func (s *SQLiteStore) migrate() error {
+	_, err := s.db.Exec(`
+		CREATE TABLE IF NOT EXISTS accounts (
+			id               TEXT PRIMARY KEY,
+			owner            TEXT NOT NULL,
+			currency         TEXT NOT NULL,
+			balance_amount   INTEGER NOT NULL
+		);
+		CREATE TABLE IF NOT EXISTS transactions (
+			id                      TEXT PRIMARY KEY,
+			account_id              TEXT NOT NULL,
+			timestamp               DATETIME NOT NULL,
+			kind                    INTEGER NOT NULL,
+			amount                  INTEGER NOT NULL,
+			currency                TEXT NOT NULL,
+			counterparty_account_id TEXT NOT NULL,
+			memo                    TEXT NOT NULL
+		);
+		CREATE INDEX IF NOT EXISTS idx_transactions_account_id ON transactions(account_id);
+		CREATE TABLE IF NOT EXISTS audit_events (
+			id          INTEGER PRIMARY KEY AUTOINCREMENT,
+			timestamp   DATETIME NOT NULL,
+			actor       TEXT NOT NULL,
+			action      TEXT NOT NULL,
+			account_id  TEXT NOT NULL,
+			amount      INTEGER NOT NULL,
+			currency    TEXT NOT NULL,
+			result      TEXT NOT NULL,
+			error_code  TEXT NOT NULL
+		);
+	`)
+	return err
+}
+
+func (s *SQLiteStore) CreateAccount(acc *Account) error {
+	_, err := s.db.Exec(
+		`INSERT INTO accounts (id, owner, currency, balance_amount) VALUES (?, ?, ?, ?)`,
+		acc.ID, acc.Owner, acc.Currency, acc.Balance.Amount,
+	)
+	return err
+}
+
+func (s *SQLiteStore) GetAccount(id string) (*Account, error) {
+	acc := &Account{ID: id}
+	row := s.db.QueryRow(`SELECT owner, currency, balance_amount FROM accounts WHERE id = ?`, id)
+	if err := row.Scan(&acc.Owner, &acc.Currency, &acc.Balance.Amount); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrAccountNotExist{ID: id}
+		}
+		return nil, err
+	}
+	acc.Balance.Currency = acc.Currency
+	return acc, nil
+}
+
+func (s *SQLiteStore) UpdateAccount(acc *Account) error {
+	res, err := s.db.Exec(
+		`UPDATE accounts SET owner = ?, currency = ?, balance_amount = ? WHERE id = ?`,
+		acc.Owner, acc.Currency, acc.Balance.Amount, acc.ID,
+	)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrAccountNotExist{ID: acc.ID}
+	}
+	return nil
+}
+
+func (s *SQLiteStore) AppendTransaction(accountID string, tx Transaction) error {
+	return s.insertTransaction(s.db, accountID, tx)
+}
+
+func (s *SQLiteStore) insertTransaction(execer sqlExecer, accountID string, tx Transaction) error {
+	if tx.ID == "" {
+		tx.ID = txID(int(time.Now().UnixNano()))
+	}
+	_, err := execer.Exec(
+		`INSERT INTO transactions (id, account_id, timestamp, kind, amount, currency, counterparty_account_id, memo)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		tx.ID, accountID, tx.Timestamp, int(tx.Kind), tx.Amount.Amount, tx.Amount.Currency, tx.CounterpartyAccountID, tx.Memo,
+	)
+	return err
+}
+
+// sqlExecer is satisfied by both *sql.DB and *sql.Tx, letting
+// insertTransaction run inside or outside a transaction.
+type sqlExecer interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+}
+
+func (s *SQLiteStore) ListTransactions(accountID string) ([]Transaction, error) {
+	if _, err := s.GetAccount(accountID); err != nil {
+		return nil, err
+	}
+	rows, err := s.db.Query(
+		`SELECT id, timestamp, kind, amount, currency, counterparty_account_id, memo
+		 FROM transactions WHERE account_id = ? ORDER BY timestamp ASC`, accountID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var txs []Transaction
+	for rows.Next() {
+		var tx Transaction
+		var kind int
+		if err := rows.Scan(&tx.ID, &tx.Timestamp, &kind, &tx.Amount.Amount, &tx.Amount.Currency, &tx.CounterpartyAccountID, &tx.Memo); err != nil {
+			return nil, err
+		}
+		tx.Kind = TransactionKind(kind)
+		txs = append(txs, tx)
+	}
+	return txs, rows.Err()
+}
+
+func (s *SQLiteStore) Deposit(accountID string, amount Money, now time.Time) (Transaction, error) {
+	txn, err := s.db.Begin()
+	if err != nil {
+		return Transaction{}, err
+	}
+	defer txn.Rollback()
+
+	var balance int64
+	if err := txn.QueryRow(`SELECT balance_amount FROM accounts WHERE id = ?`, accountID).Scan(&balance); err != nil {
+		if err == sql.ErrNoRows {
+			return Transaction{}, ErrAccountNotExist{ID: accountID}
+		}
+		return Transaction{}, err
+	}
+
+	if _, err := txn.Exec(`UPDATE accounts SET balance_amount = ? WHERE id = ?`, balance+amount.Amount, accountID); err != nil {
+		return Transaction{}, err
+	}
+
+	tx := Transaction{ID: txID(int(now.UnixNano())), Timestamp: now, Kind: Deposit, Amount: amount}
+	if err := s.insertTransaction(txn, accountID, tx); err != nil {
+		return Transaction{}, err
+	}
+	return tx, txn.Commit()
+}
+
+func (s *SQLiteStore) Withdraw(accountID string, amount Money, now time.Time) (Transaction, error) {
+	txn, err := s.db.Begin()
+	if err != nil {
+		return Transaction{}, err
+	}
+	defer txn.Rollback()
+
+	var balance int64
+	if err := txn.QueryRow(`SELECT balance_amount FROM accounts WHERE id = ?`, accountID).Scan(&balance); err != nil {
+		if err == sql.ErrNoRows {
+			return Transaction{}, ErrAccountNotExist{ID: accountID}
+		}
+		return Transaction{}, err
+	}
+	if amount.Amount > balance {
+		return Transaction{}, ErrOverdraft{Requested: amount, Balance: Money{Amount: balance, Currency: amount.Currency}}
+	}
+
+	if _, err := txn.Exec(`UPDATE accounts SET balance_amount = ? WHERE id = ?`, balance-amount.Amount, accountID); err != nil {
+		return Transaction{}, err
+	}
+
+	tx := Transaction{ID: txID(int(now.UnixNano())), Timestamp: now, Kind: Withdraw, Amount: amount}
+	if err := s.insertTransaction(txn, accountID, tx); err != nil {
+		return Transaction{}, err
+	}
+	return tx, txn.Commit()
+}
+
+func (s *SQLiteStore) Transfer(fromID, toID string, debit, credit Money, memo string, now time.Time) (out, in Transaction, err error) {
+	txn, err := s.db.Begin()
+	if err != nil {
+		return Transaction{}, Transaction{}, err
+	}
+	defer txn.Rollback()
+
+	var fromBalance int64
+	if err := txn.QueryRow(`SELECT balance_amount FROM accounts WHERE id = ?`, fromID).Scan(&fromBalance); err != nil {
+		if err == sql.ErrNoRows {
+			return Transaction{}, Transaction{}, ErrAccountNotExist{ID: fromID}
+		}
+		return Transaction{}, Transaction{}, err
+	}
+	var toBalance int64
+	if err := txn.QueryRow(`SELECT balance_amount FROM accounts WHERE id = ?`, toID).Scan(&toBalance); err != nil {
+		if err == sql.ErrNoRows {
+			return Transaction{}, Transaction{}, ErrAccountNotExist{ID: toID}
+		}
+		return Transaction{}, Transaction{}, err
+	}
+	if !debit.IsPositive() {
+		return Transaction{}, Transaction{}, ErrNonPositiveAmount{Amount: debit}
+	}
+	if debit.Amount > fromBalance {
+		return Transaction{}, Transaction{}, ErrOverdraft{Requested: debit, Balance: Money{Amount: fromBalance, Currency: debit.Currency}}
+	}
+
+	if _, err := txn.Exec(`UPDATE accounts SET balance_amount = ? WHERE id = ?`, fromBalance-debit.Amount, fromID); err != nil {
+		return Transaction{}, Transaction{}, err
+	}
+	if _, err := txn.Exec(`UPDATE accounts SET balance_amount = ? WHERE id = ?`, toBalance+credit.Amount, toID); err != nil {
+		return Transaction{}, Transaction{}, err
+	}
+
+	out = Transaction{ID: txID(int(now.UnixNano())), Timestamp: now, Kind: TransferOut, Amount: debit, CounterpartyAccountID: toID, Memo: memo}
+	in = Transaction{ID: txID(int(now.UnixNano()) + 1), Timestamp: now, Kind: TransferIn, Amount: credit, CounterpartyAccountID: fromID, Memo: memo}
+
+	if err := s.insertTransaction(txn, fromID, out); err != nil {
+		return Transaction{}, Transaction{}, err
+	}
+	if err := s.insertTransaction(txn, toID, in); err != nil {
+		return Transaction{}, Transaction{}, err
+	}
+
+	return out, in, txn.Commit()
+}
+
+// Record implements AuditSink by appending e to the audit_events table.
+// Errors are swallowed, matching FileAuditSink's Record: an audit write
+// failure must not abort the mutating call it describes.
+func (s *SQLiteStore) Record(e AuditEvent) {
+	_, _ = s.db.Exec(
+		`INSERT INTO audit_events (timestamp, actor, action, account_id, amount, currency, result, error_code)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		e.Timestamp, e.Actor, e.Action, e.AccountID, e.Amount.Amount, e.Amount.Currency, e.Result, e.ErrorCode,
+	)
+}
+
+// Query implements AuditQuerier over the audit_events table.
+func (s *SQLiteStore) Query(filter LogFilter) ([]AuditEvent, error) {
+	rows, err := s.db.Query(
+		`SELECT timestamp, actor, action, account_id, amount, currency, result, error_code
+		 FROM audit_events ORDER BY id ASC`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []AuditEvent
+	for rows.Next() {
+		var e AuditEvent
+		if err := rows.Scan(&e.Timestamp, &e.Actor, &e.Action, &e.AccountID, &e.Amount.Amount, &e.Amount.Currency, &e.Result, &e.ErrorCode); err != nil {
+			return nil, err
+		}
+		if filter.matches(e) {
+			out = append(out, e)
+		}
+	}
+	return out, rows.Err()
+}
@@ -0,0 +1,110 @@
+package bank
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// TestSQLiteStore exercises SQLiteStore through BankLibrary exactly as
+// MemoryStore is exercised in TestBankAPI/TestTransfer, and confirms
+// balances and the ledger survive closing and reopening the database --
+// the durability MemoryStore cannot offer.
+func TestSQLiteStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bank.db")
+
+	store, err := NewSQLiteStore(path)
+	if err != nil {
+		t.Fatalf("NewSQLiteStore failed: %v", err)
+	}
+	lib := NewBankLibraryWithStore(store)
+
+	alice := lib.CreateAccount("Alice", "USD")
+	bob := lib.CreateAccount("Bob", "USD")
+
+	if err := lib.Deposit(alice, MustParseMoney("100 USD")); err != nil {
+		t.Fatalf("deposit failed: %v", err)
+	}
+	if err := lib.Withdraw(alice, MustParseMoney("20 USD")); err != nil {
+		t.Fatalf("withdraw failed: %v", err)
+	}
+	if err := lib.Transfer(alice, bob, MustParseMoney("30 USD"), "rent"); err != nil {
+		t.Fatalf("transfer failed: %v", err)
+	}
+	if err := store.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	// Reopen against the same file to confirm the ledger survived the
+	// "restart".
+	reopened, err := NewSQLiteStore(path)
+	if err != nil {
+		t.Fatalf("reopening NewSQLiteStore failed: %v", err)
+	}
+	defer reopened.Close()
+	restored := NewBankLibraryWithStore(reopened)
+
+	aliceBalance, err := restored.Balance(alice)
+	if err != nil {
+		t.Fatalf("Balance failed: %v", err)
+	}
+	if aliceBalance != MustParseMoney("50 USD") {
+		t.Errorf("expected Alice's balance to be 50 USD after reopen, got %v", aliceBalance)
+	}
+	bobBalance, err := restored.Balance(bob)
+	if err != nil {
+		t.Fatalf("Balance failed: %v", err)
+	}
+	if bobBalance != MustParseMoney("30 USD") {
+		t.Errorf("expected Bob's balance to be 30 USD after reopen, got %v", bobBalance)
+	}
+
+	txs, err := restored.TransactionsBetween(alice, time.Now().Add(-time.Hour), time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatalf("TransactionsBetween failed: %v", err)
+	}
+	if len(txs) != 3 {
+		t.Fatalf("expected 3 transactions (deposit, withdraw, transfer-out), got %d: %+v", len(txs), txs)
+	}
+
+	if err := restored.Withdraw(alice, MustParseMoney("1000 USD")); !IsErrOverdraft(err) {
+		t.Errorf("expected ErrOverdraft, got %v", err)
+	}
+}
+
+// TestSQLiteStoreAudit guards against the audit trail being lost across
+// restarts when a BankLibrary is wired with SQLiteStore as its own
+// AuditSink (via NewBankLibraryWithAudit(store, store)), the same way
+// CLI -db mode does.
+func TestSQLiteStoreAudit(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bank.db")
+
+	store, err := NewSQLiteStore(path)
+	if err != nil {
+		t.Fatalf("NewSQLiteStore failed: %v", err)
+	}
+	lib := NewBankLibraryWithAudit(store, store)
+
+	id := lib.CreateAccount("Alice", "USD")
+	if err := lib.Deposit(id, MustParseMoney("100 USD")); err != nil {
+		t.Fatalf("deposit failed: %v", err)
+	}
+	if err := store.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	reopened, err := NewSQLiteStore(path)
+	if err != nil {
+		t.Fatalf("reopening NewSQLiteStore failed: %v", err)
+	}
+	defer reopened.Close()
+	restored := NewBankLibraryWithAudit(reopened, reopened)
+
+	events, err := restored.Logs(LogFilter{AccountID: id})
+	if err != nil {
+		t.Fatalf("Logs failed: %v", err)
+	}
+	if len(events) != 2 || events[0].Action != "CreateAccount" || events[1].Action != "Deposit" {
+		t.Errorf("expected CreateAccount and Deposit events to survive the restart, got %+v", events)
+	}
+}
@@ -0,0 +1,75 @@
+package bank
+
+import (
+	"errors"
+	"sort"
+)
+
+// State is a point-in-time snapshot of a BankLibrary's accounts, ledger,
+// and audit trail, suitable for JSON marshaling so a caller (such as the
+// CLI's --state flag) can persist it across process restarts.
+type State struct {
+	Accounts      []Account
+	Transactions  map[string][]Transaction
+	NextAccountID int
+	// Events holds the audit trail captured so far. It is populated only
+	// when the BankLibrary's AuditSink implements AuditQuerier (e.g.
+	// MemoryAuditSink); write-only sinks such as FileAuditSink keep their
+	// own durable history instead.
+	Events []AuditEvent
+}
+
+// ExportState snapshots b's state. It only supports BankLibrary
+// instances backed by a MemoryStore (the default); callers using
+// SQLiteStore get persistence from the database itself instead.
+func (b *BankLibrary) ExportState() (State, error) {
+	ms, ok := b.store.(*MemoryStore)
+	if !ok {
+		return State{}, errNotMemoryStore
+	}
+
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	accounts := make([]Account, 0, len(ms.accounts))
+	for _, acc := range ms.accounts {
+		accounts = append(accounts, *acc)
+	}
+	sort.Slice(accounts, func(i, j int) bool { return accounts[i].ID < accounts[j].ID })
+
+	txs := make(map[string][]Transaction, len(ms.transactions))
+	for id, list := range ms.transactions {
+		txs[id] = append([]Transaction(nil), list...)
+	}
+
+	var events []AuditEvent
+	if q, ok := b.audit.(AuditQuerier); ok {
+		events, _ = q.Query(LogFilter{})
+	}
+
+	return State{Accounts: accounts, Transactions: txs, NextAccountID: b.nextAccountID, Events: events}, nil
+}
+
+// NewBankLibraryFromState restores a BankLibrary previously captured
+// with ExportState, backed by a fresh MemoryStore. state.Events, if any,
+// are replayed into audit so that Logs sees history from before the
+// restart.
+func NewBankLibraryFromState(state State, audit AuditSink, fx FXRateProvider) *BankLibrary {
+	store := NewMemoryStore()
+	for _, acc := range state.Accounts {
+		accCopy := acc
+		store.accounts[acc.ID] = &accCopy
+	}
+	for id, list := range state.Transactions {
+		store.transactions[id] = append([]Transaction(nil), list...)
+	}
+	for _, e := range state.Events {
+		audit.Record(e)
+	}
+
+	lib := NewBankLibraryWithFX(store, audit, fx)
+	lib.nextAccountID = state.NextAccountID
+	return lib
+}
+
+var errNotMemoryStore = errors.New("ExportState only supports a MemoryStore-backed BankLibrary")
@@ -0,0 +1,204 @@
+package bank
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Store persists accounts and their transaction ledgers. BankLibrary
+// delegates all state to a Store so that callers can choose between the
+// in-memory implementation (the default, used by NewBankLibrary) and a
+// durable one such as SQLiteStore.
+type Store interface {
+	CreateAccount(acc *Account) error
+	// GetAccount returns a copy of the account; mutating the result does
+	// not affect the store. Use UpdateAccount, Deposit, Withdraw, or
+	// Transfer to persist changes.
+	GetAccount(id string) (*Account, error)
+	UpdateAccount(acc *Account) error
+	AppendTransaction(accountID string, tx Transaction) error
+	ListTransactions(accountID string) ([]Transaction, error)
+
+	// Deposit atomically credits accountID by amount and records the
+	// resulting Deposit transaction.
+	Deposit(accountID string, amount Money, now time.Time) (Transaction, error)
+	// Withdraw atomically debits accountID by amount, failing with
+	// ErrOverdraft if amount exceeds the current balance, and records
+	// the resulting Withdraw transaction.
+	Withdraw(accountID string, amount Money, now time.Time) (Transaction, error)
+
+	// Transfer atomically debits fromID by debit, credits toID by
+	// credit, and records the paired TransferOut/TransferIn
+	// transactions. debit and credit differ when the two accounts hold
+	// different currencies; the caller is responsible for computing
+	// credit via the effective FX rate.
+	Transfer(fromID, toID string, debit, credit Money, memo string, now time.Time) (out, in Transaction, err error)
+}
+
+// MemoryStore is an in-memory Store. It is the default backing for
+// NewBankLibrary and does not survive process restarts.
+type MemoryStore struct {
+	mu           sync.Mutex
+	accounts     map[string]*Account
+	transactions map[string][]Transaction
+	nextTxID     int
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		accounts:     make(map[string]*Account),
+		transactions: make(map[string][]Transaction),
+	}
+}
+
+func (s *MemoryStore) CreateAccount(acc *Account) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.accounts[acc.ID] = acc
+	return nil
+}
+
+func (s *MemoryStore) GetAccount(id string) (*Account, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	acc, exists := s.accounts[id]
+	if !exists {
+		return nil, ErrAccountNotExist{ID: id}
+	}
+	cp := *acc
+	return &cp, nil
+}
+
+func (s *MemoryStore) UpdateAccount(acc *Account) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exists := s.accounts[acc.ID]; !exists {
+		return ErrAccountNotExist{ID: acc.ID}
+	}
+	s.accounts[acc.ID] = acc
+	return nil
+}
+
+func (s *MemoryStore) AppendTransaction(accountID string, tx Transaction) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exists := s.accounts[accountID]; !exists {
+		return ErrAccountNotExist{ID: accountID}
+	}
+	if tx.ID == "" {
+		s.nextTxID++
+		tx.ID = txID(s.nextTxID)
+	}
+	s.transactions[accountID] = append(s.transactions[accountID], tx)
+	return nil
+}
+
+func (s *MemoryStore) ListTransactions(accountID string) ([]Transaction, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exists := s.accounts[accountID]; !exists {
+		return nil, ErrAccountNotExist{ID: accountID}
+	}
+	txs := make([]Transaction, len(s.transactions[accountID]))
+	copy(txs, s.transactions[accountID])
+	return txs, nil
+}
+
+func (s *MemoryStore) Deposit(accountID string, amount Money, now time.Time) (Transaction, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	acc, exists := s.accounts[accountID]
+	if !exists {
+		return Transaction{}, ErrAccountNotExist{ID: accountID}
+	}
+	acc.Balance.Amount += amount.Amount
+
+	s.nextTxID++
+	tx := Transaction{ID: txID(s.nextTxID), Timestamp: now, Kind: Deposit, Amount: amount}
+	s.transactions[accountID] = append(s.transactions[accountID], tx)
+	return tx, nil
+}
+
+func (s *MemoryStore) Withdraw(accountID string, amount Money, now time.Time) (Transaction, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	acc, exists := s.accounts[accountID]
+	if !exists {
+		return Transaction{}, ErrAccountNotExist{ID: accountID}
+	}
+	if amount.Amount > acc.Balance.Amount {
+		return Transaction{}, ErrOverdraft{Requested: amount, Balance: acc.Balance}
+	}
+	acc.Balance.Amount -= amount.Amount
+
+	s.nextTxID++
+	tx := Transaction{ID: txID(s.nextTxID), Timestamp: now, Kind: Withdraw, Amount: amount}
+	s.transactions[accountID] = append(s.transactions[accountID], tx)
+	return tx, nil
+}
+
+func (s *MemoryStore) Transfer(fromID, toID string, debit, credit Money, memo string, now time.Time) (out, in Transaction, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	from, exists := s.accounts[fromID]
+	if !exists {
+		return Transaction{}, Transaction{}, ErrAccountNotExist{ID: fromID}
+	}
+	to, exists := s.accounts[toID]
+	if !exists {
+		return Transaction{}, Transaction{}, ErrAccountNotExist{ID: toID}
+	}
+	if !debit.IsPositive() {
+		return Transaction{}, Transaction{}, ErrNonPositiveAmount{Amount: debit}
+	}
+	if debit.Amount > from.Balance.Amount {
+		return Transaction{}, Transaction{}, ErrOverdraft{Requested: debit, Balance: from.Balance}
+	}
+
+	from.Balance.Amount -= debit.Amount
+	to.Balance.Amount += credit.Amount
+
+	s.nextTxID++
+	out = Transaction{ID: txID(s.nextTxID), Timestamp: now, Kind: TransferOut, Amount: debit, CounterpartyAccountID: toID, Memo: memo}
+	s.nextTxID++
+	in = Transaction{ID: txID(s.nextTxID), Timestamp: now, Kind: TransferIn, Amount: credit, CounterpartyAccountID: fromID, Memo: memo}
+
+	s.transactions[fromID] = append(s.transactions[fromID], out)
+	s.transactions[toID] = append(s.transactions[toID], in)
+	return out, in, nil
+}
+
+func txID(n int) string {
+	return fmt.Sprintf("TX-%d", n)
+}
+
+// sortTransactions sorts txs in place by the given field ("date" or
+// "amount"); any other value is treated as "date". Prefix "-" reverses
+// the order, mirroring the getAccountTransactions(page, limit, sort)
+// convention of leading-minus-for-descending.
+func sortTransactions(txs []Transaction, sortBy string) {
+	desc := false
+	field := sortBy
+	if len(field) > 0 && field[0] == '-' {
+		desc = true
+		field = field[1:]
+	}
+	less := func(i, j int) bool {
+		switch field {
+		case "amount":
+			return txs[i].Amount.Amount < txs[j].Amount.Amount
+		default:
+			return txs[i].Timestamp.Before(txs[j].Timestamp)
+		}
+	}
+	if desc {
+		sort.SliceStable(txs, func(i, j int) bool { return less(j, i) })
+	} else {
+		sort.SliceStable(txs, func(i, j int) bool { return less(i, j) })
+	}
+}
@@ -0,0 +1,41 @@
+package bank
+
+import "time"
+
+// TransactionKind identifies what kind of ledger entry a Transaction
+// represents.
+type TransactionKind int
+
+const (
+	Deposit TransactionKind = iota
+	Withdraw
+	TransferIn
+	TransferOut
+)
+
+func (k TransactionKind) String() string {
+	switch k {
+	case Deposit:
+		return "deposit"
+	case Withdraw:
+		return "withdraw"
+	case TransferIn:
+		return "transfer_in"
+	case TransferOut:
+		return "transfer_out"
+	default:
+		return "unknown"
+	}
+}
+
+// Transaction is a single ledger entry for an account. Transfers are
+// recorded as a paired TransferOut/TransferIn on the two accounts
+// involved, linked by CounterpartyAccountID.
+type Transaction struct {
+	ID                    string
+	Timestamp             time.Time
+	Kind                  TransactionKind
+	Amount                Money
+	CounterpartyAccountID string
+	Memo                  string
+}
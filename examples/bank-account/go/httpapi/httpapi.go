@@ -0,0 +1,197 @@
+// Package httpapi mounts a bank.BankAPI behind a REST surface.
+package httpapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/drhodes/libspec/examples/bank-account/go/bank"
+)
+
+// Server adapts a bank.BankAPI to net/http.
+type Server struct {
+	lib bank.BankAPI
+}
+
+func NewServer(lib bank.BankAPI) *Server {
+	return &Server{lib: lib}
+}
+
+// Handler returns the mux exposing every route.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /v1/accounts", s.createAccount)
+	mux.HandleFunc("GET /v1/accounts/{id}", s.getAccount)
+	mux.HandleFunc("POST /v1/accounts/{id}/deposit", s.deposit)
+	mux.HandleFunc("POST /v1/accounts/{id}/withdraw", s.withdraw)
+	mux.HandleFunc("POST /v1/transfers", s.transfer)
+	mux.HandleFunc("GET /v1/accounts/{id}/transactions", s.transactions)
+	return mux
+}
+
+type errorEnvelope struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// writeError maps a BankAPI error onto an HTTP status and the stable
+// {code, message} JSON envelope.
+func writeError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case bank.IsErrAccountNotExist(err):
+		status = http.StatusNotFound
+	case bank.IsErrNonPositiveAmount(err), bank.IsErrCurrencyMismatch(err):
+		status = http.StatusBadRequest
+	case bank.IsErrOverdraft(err):
+		status = http.StatusConflict
+	}
+	writeJSON(w, status, errorEnvelope{Code: status, Message: err.Error()})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+type createAccountRequest struct {
+	Owner    string `json:"owner"`
+	Currency string `json:"currency"`
+}
+
+type createAccountResponse struct {
+	ID string `json:"id"`
+}
+
+func (s *Server) createAccount(w http.ResponseWriter, r *http.Request) {
+	var req createAccountRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorEnvelope{Code: http.StatusBadRequest, Message: err.Error()})
+		return
+	}
+	id := s.lib.CreateAccount(req.Owner, req.Currency)
+	writeJSON(w, http.StatusCreated, createAccountResponse{ID: id})
+}
+
+type accountResponse struct {
+	ID      string     `json:"id"`
+	Balance bank.Money `json:"balance"`
+}
+
+func (s *Server) getAccount(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	balance, err := s.lib.Balance(id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, accountResponse{ID: id, Balance: balance})
+}
+
+type amountRequest struct {
+	Amount string `json:"amount"`
+}
+
+func (s *Server) deposit(w http.ResponseWriter, r *http.Request) {
+	s.applyAmount(w, r, s.lib.Deposit)
+}
+
+func (s *Server) withdraw(w http.ResponseWriter, r *http.Request) {
+	s.applyAmount(w, r, s.lib.Withdraw)
+}
+
+func (s *Server) applyAmount(w http.ResponseWriter, r *http.Request, apply func(accountID string, amount bank.Money) error) {
+	id := r.PathValue("id")
+	var req amountRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorEnvelope{Code: http.StatusBadRequest, Message: err.Error()})
+		return
+	}
+	amount, err := bank.ParseMoney(req.Amount)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, errorEnvelope{Code: http.StatusBadRequest, Message: err.Error()})
+		return
+	}
+	if err := apply(id, amount); err != nil {
+		writeError(w, err)
+		return
+	}
+	balance, err := s.lib.Balance(id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, accountResponse{ID: id, Balance: balance})
+}
+
+type transferRequest struct {
+	From   string `json:"from"`
+	To     string `json:"to"`
+	Amount string `json:"amount"`
+	Memo   string `json:"memo"`
+}
+
+func (s *Server) transfer(w http.ResponseWriter, r *http.Request) {
+	var req transferRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorEnvelope{Code: http.StatusBadRequest, Message: err.Error()})
+		return
+	}
+	amount, err := bank.ParseMoney(req.Amount)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, errorEnvelope{Code: http.StatusBadRequest, Message: err.Error()})
+		return
+	}
+	if err := s.lib.Transfer(req.From, req.To, amount, req.Memo); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+type cursor struct {
+	Next    int  `json:"next"`
+	HasMore bool `json:"hasMore"`
+}
+
+type transactionsResponse struct {
+	Data   []bank.Transaction `json:"data"`
+	Cursor cursor             `json:"cursor"`
+}
+
+func (s *Server) transactions(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	page := queryInt(r, "page", 1)
+	limit := queryInt(r, "limit", 20)
+	sort := r.URL.Query().Get("sort")
+	if sort == "" {
+		sort = "date"
+	}
+
+	txs, total, err := s.lib.TransactionsPage(id, page, limit, sort)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	hasMore := page*limit < total
+	next := 0
+	if hasMore {
+		next = page + 1
+	}
+	writeJSON(w, http.StatusOK, transactionsResponse{Data: txs, Cursor: cursor{Next: next, HasMore: hasMore}})
+}
+
+func queryInt(r *http.Request, name string, def int) int {
+	s := r.URL.Query().Get(name)
+	if s == "" {
+		return def
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil || n <= 0 {
+		return def
+	}
+	return n
+}
@@ -0,0 +1,172 @@
+package httpapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/drhodes/libspec/examples/bank-account/go/bank"
+)
+
+func newTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	lib := bank.NewBankLibrary()
+	srv := httptest.NewServer(NewServer(lib).Handler())
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func postJSON(t *testing.T, url string, body interface{}) *http.Response {
+	t.Helper()
+	data, err := json.Marshal(body)
+	if err != nil {
+		t.Fatalf("marshal request: %v", err)
+	}
+	resp, err := http.Post(url, "application/json", bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("POST %s: %v", url, err)
+	}
+	return resp
+}
+
+func decode(t *testing.T, resp *http.Response, v interface{}) {
+	t.Helper()
+	defer resp.Body.Close()
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+}
+
+func TestCreateAndGetAccount(t *testing.T) {
+	srv := newTestServer(t)
+
+	resp := postJSON(t, srv.URL+"/v1/accounts", createAccountRequest{Owner: "Alice", Currency: "USD"})
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("expected 201, got %d", resp.StatusCode)
+	}
+	var created createAccountResponse
+	decode(t, resp, &created)
+	if created.ID == "" {
+		t.Fatal("expected a non-empty account ID")
+	}
+
+	resp = mustGet(t, srv.URL+"/v1/accounts/"+created.ID)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d", resp.StatusCode)
+	}
+	var acc accountResponse
+	decode(t, resp, &acc)
+	if acc.Balance != bank.MustParseMoney("0 USD") {
+		t.Errorf("expected a zero balance, got %v", acc.Balance)
+	}
+}
+
+func TestGetAccountNotFound(t *testing.T) {
+	srv := newTestServer(t)
+
+	resp := mustGet(t, srv.URL+"/v1/accounts/missing")
+	if resp.StatusCode != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d", resp.StatusCode)
+	}
+	var envelope errorEnvelope
+	decode(t, resp, &envelope)
+	if envelope.Code != http.StatusNotFound {
+		t.Errorf("expected envelope code 404, got %d", envelope.Code)
+	}
+}
+
+func TestDepositAndWithdraw(t *testing.T) {
+	srv := newTestServer(t)
+	id := createAccount(t, srv.URL, "Alice", "USD")
+
+	resp := postJSON(t, srv.URL+"/v1/accounts/"+id+"/deposit", amountRequest{Amount: "100 USD"})
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d", resp.StatusCode)
+	}
+	var acc accountResponse
+	decode(t, resp, &acc)
+	if acc.Balance != bank.MustParseMoney("100 USD") {
+		t.Errorf("expected balance 100 USD, got %v", acc.Balance)
+	}
+
+	resp = postJSON(t, srv.URL+"/v1/accounts/"+id+"/withdraw", amountRequest{Amount: "500 USD"})
+	if resp.StatusCode != http.StatusConflict {
+		t.Fatalf("expected 409 for overdraft, got %d", resp.StatusCode)
+	}
+
+	resp = postJSON(t, srv.URL+"/v1/accounts/"+id+"/deposit", amountRequest{Amount: "-5 USD"})
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected 400 for non-positive amount, got %d", resp.StatusCode)
+	}
+}
+
+func TestTransferEndpoint(t *testing.T) {
+	srv := newTestServer(t)
+	alice := createAccount(t, srv.URL, "Alice", "USD")
+	bob := createAccount(t, srv.URL, "Bob", "USD")
+
+	resp := postJSON(t, srv.URL+"/v1/accounts/"+alice+"/deposit", amountRequest{Amount: "100 USD"})
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("deposit failed: %d", resp.StatusCode)
+	}
+
+	resp = postJSON(t, srv.URL+"/v1/transfers", transferRequest{From: alice, To: bob, Amount: "40 USD", Memo: "rent"})
+	if resp.StatusCode != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d", resp.StatusCode)
+	}
+
+	resp = mustGet(t, srv.URL+"/v1/accounts/"+bob)
+	var acc accountResponse
+	decode(t, resp, &acc)
+	if acc.Balance != bank.MustParseMoney("40 USD") {
+		t.Errorf("expected Bob's balance to be 40 USD, got %v", acc.Balance)
+	}
+}
+
+func TestTransactionsPagination(t *testing.T) {
+	srv := newTestServer(t)
+	id := createAccount(t, srv.URL, "Alice", "USD")
+
+	for i := 1; i <= 5; i++ {
+		resp := postJSON(t, srv.URL+"/v1/accounts/"+id+"/deposit", amountRequest{Amount: fmt.Sprintf("%d USD", i)})
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("deposit %d failed: %d", i, resp.StatusCode)
+		}
+	}
+
+	resp := mustGet(t, fmt.Sprintf("%s/v1/accounts/%s/transactions?page=1&limit=2&sort=-amount", srv.URL, id))
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d", resp.StatusCode)
+	}
+	var page transactionsResponse
+	decode(t, resp, &page)
+	if len(page.Data) != 2 {
+		t.Fatalf("expected 2 transactions, got %d", len(page.Data))
+	}
+	if page.Data[0].Amount != bank.MustParseMoney("5 USD") || page.Data[1].Amount != bank.MustParseMoney("4 USD") {
+		t.Errorf("expected descending page [5 4], got %+v", page.Data)
+	}
+	if !page.Cursor.HasMore || page.Cursor.Next != 2 {
+		t.Errorf("expected cursor {next:2 hasMore:true}, got %+v", page.Cursor)
+	}
+}
+
+func createAccount(t *testing.T, baseURL, owner, currency string) string {
+	t.Helper()
+	resp := postJSON(t, baseURL+"/v1/accounts", createAccountRequest{Owner: owner, Currency: currency})
+	var created createAccountResponse
+	decode(t, resp, &created)
+	return created.ID
+}
+
+func mustGet(t *testing.T, url string) *http.Response {
+	t.Helper()
+	resp, err := http.Get(url)
+	if err != nil {
+		t.Fatalf("GET %s: %v", url, err)
+	}
+	return resp
+}
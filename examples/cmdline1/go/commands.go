@@ -0,0 +1,466 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/drhodes/libspec/examples/bank-account/go/bank"
+)
+
+// Command is a single CLI subcommand, in the spirit of a Name /
+// Description / Flags / CheckFlags / Do record: Flags defines the
+// subcommand's flags, CheckFlags validates them once parsed, and Do
+// performs the work.
+type Command struct {
+	Name        string
+	Description string
+	Flags       *flag.FlagSet
+	CheckFlags  func() error
+	Do          func() error
+}
+
+// newFlagSet creates a FlagSet for a subcommand pre-registered with the
+// flags every subcommand shares: --state (the JSON state file to load
+// and save), --db (a SQLite database file for durable storage instead),
+// and --json (machine-readable output).
+func newFlagSet(name string) (fs *flag.FlagSet, state *string, dbPath *string, jsonOut *bool) {
+	fs = flag.NewFlagSet(name, flag.ExitOnError)
+	state = fs.String("state", "bank-state.json", "path to the JSON state file (ignored if -db is set)")
+	dbPath = fs.String("db", "", "path to a SQLite database file; if set, accounts and the ledger are stored there instead of -state")
+	jsonOut = fs.Bool("json", false, "emit machine-readable JSON output")
+	return fs, state, dbPath, jsonOut
+}
+
+// printResult prints data as indented JSON if jsonOut, else prints
+// human as a plain line.
+func printResult(jsonOut bool, human string, data interface{}) error {
+	if !jsonOut {
+		fmt.Println(human)
+		return nil
+	}
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(data)
+}
+
+func requireNonEmpty(flagName, value string) error {
+	if value == "" {
+		return fmt.Errorf("-%s is required", flagName)
+	}
+	return nil
+}
+
+// parseFXRates parses a comma-separated list of "FROM->TO=RATE" pairs,
+// e.g. "USD->EUR=0.92,EUR->USD=1.09", into the FXRateProvider a transfer
+// between differing currencies needs. An empty string returns nil,
+// leaving openLibrary/loadLibrary to fall back to their no-rates
+// default, which is enough for same-currency transfers.
+func parseFXRates(s string) (bank.FXRateProvider, error) {
+	if s == "" {
+		return nil, nil
+	}
+	rates := make(map[string]float64)
+	for _, pair := range strings.Split(s, ",") {
+		kv := strings.SplitN(pair, "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("invalid -fx-rates entry %q: want FROM->TO=RATE", pair)
+		}
+		rate, err := strconv.ParseFloat(kv[1], 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid -fx-rates entry %q: %w", pair, err)
+		}
+		rates[kv[0]] = rate
+	}
+	return bank.NewStaticFXRateProvider(rates), nil
+}
+
+func loadLibrary(path string, fx bank.FXRateProvider) (*bank.BankLibrary, error) {
+	if fx == nil {
+		fx = bank.NewStaticFXRateProvider(nil)
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return bank.NewBankLibraryWithFX(bank.NewMemoryStore(), bank.NewMemoryAuditSink(0), fx), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var state bank.State
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return bank.NewBankLibraryFromState(state, bank.NewMemoryAuditSink(0), fx), nil
+}
+
+func saveLibrary(path string, lib *bank.BankLibrary) error {
+	state, err := lib.ExportState()
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// openLibrary opens a BankLibrary for a single subcommand invocation. If
+// dbPath is set, it opens a durable SQLiteStore-backed library, using
+// the same SQLiteStore as its AuditSink so that logs sees history
+// across invocations (every mutating call and audit event is already
+// persisted to dbPath, so persistLibrary is a no-op); otherwise it
+// falls back to the JSON --state file via loadLibrary. fx is the
+// FXRateProvider to transfer cross-currency amounts with; pass nil to
+// get the no-rates default (same-currency transfers only). The
+// returned closer must be called once the command is done with the
+// library.
+func openLibrary(dbPath, statePath string, fx bank.FXRateProvider) (lib *bank.BankLibrary, closer func() error, err error) {
+	if fx == nil {
+		fx = bank.NewStaticFXRateProvider(nil)
+	}
+	if dbPath != "" {
+		store, err := bank.NewSQLiteStore(dbPath)
+		if err != nil {
+			return nil, nil, err
+		}
+		return bank.NewBankLibraryWithFX(store, store, fx), store.Close, nil
+	}
+	lib, err = loadLibrary(statePath, fx)
+	return lib, func() error { return nil }, err
+}
+
+// persistLibrary saves lib back to statePath's JSON state file, unless
+// dbPath is set, in which case SQLiteStore has already persisted every
+// mutation as it happened.
+func persistLibrary(dbPath, statePath string, lib *bank.BankLibrary) error {
+	if dbPath != "" {
+		return nil
+	}
+	return saveLibrary(statePath, lib)
+}
+
+func newCreateAccountCommand() *Command {
+	fs, state, dbPath, jsonOut := newFlagSet("create-account")
+	owner := fs.String("owner", "", "the new account's owner name")
+	currency := fs.String("currency", "USD", "the new account's currency, e.g. USD")
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "create-account: open a new account")
+		fs.PrintDefaults()
+	}
+
+	return &Command{
+		Name:        "create-account",
+		Description: "open a new account",
+		Flags:       fs,
+		CheckFlags:  func() error { return requireNonEmpty("owner", *owner) },
+		Do: func() error {
+			lib, closeLib, err := openLibrary(*dbPath, *state, nil)
+			if err != nil {
+				return err
+			}
+			defer closeLib()
+			id := lib.CreateAccount(*owner, *currency)
+			if err := persistLibrary(*dbPath, *state, lib); err != nil {
+				return err
+			}
+			return printResult(*jsonOut, id, struct{ AccountID string }{id})
+		},
+	}
+}
+
+func newDepositCommand() *Command  { return newMoneyCommand("deposit", "deposit into an account") }
+func newWithdrawCommand() *Command { return newMoneyCommand("withdraw", "withdraw from an account") }
+
+// newMoneyCommand builds the deposit and withdraw subcommands, which
+// share the same -account/-amount flags and only differ in which
+// BankLibrary method they call.
+func newMoneyCommand(name, description string) *Command {
+	fs, state, dbPath, jsonOut := newFlagSet(name)
+	account := fs.String("account", "", "the account ID")
+	amount := fs.String("amount", "", `the amount, e.g. "12.34 USD"`)
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "%s: %s\n", name, description)
+		fs.PrintDefaults()
+	}
+
+	return &Command{
+		Name:        name,
+		Description: description,
+		Flags:       fs,
+		CheckFlags: func() error {
+			if err := requireNonEmpty("account", *account); err != nil {
+				return err
+			}
+			return requireNonEmpty("amount", *amount)
+		},
+		Do: func() error {
+			money, err := bank.ParseMoney(*amount)
+			if err != nil {
+				return err
+			}
+			lib, closeLib, err := openLibrary(*dbPath, *state, nil)
+			if err != nil {
+				return err
+			}
+			defer closeLib()
+			if name == "deposit" {
+				err = lib.Deposit(*account, money)
+			} else {
+				err = lib.Withdraw(*account, money)
+			}
+			if err != nil {
+				return err
+			}
+			if err := persistLibrary(*dbPath, *state, lib); err != nil {
+				return err
+			}
+			balance, err := lib.Balance(*account)
+			if err != nil {
+				return err
+			}
+			human := fmt.Sprintf("%s: new balance %s", *account, balance)
+			return printResult(*jsonOut, human, struct {
+				AccountID string
+				Balance   bank.Money
+			}{*account, balance})
+		},
+	}
+}
+
+func newBalanceCommand() *Command {
+	fs, state, dbPath, jsonOut := newFlagSet("balance")
+	account := fs.String("account", "", "the account ID")
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "balance: print an account's balance")
+		fs.PrintDefaults()
+	}
+
+	return &Command{
+		Name:        "balance",
+		Description: "print an account's balance",
+		Flags:       fs,
+		CheckFlags:  func() error { return requireNonEmpty("account", *account) },
+		Do: func() error {
+			lib, closeLib, err := openLibrary(*dbPath, *state, nil)
+			if err != nil {
+				return err
+			}
+			defer closeLib()
+			balance, err := lib.Balance(*account)
+			if err != nil {
+				return err
+			}
+			return printResult(*jsonOut, balance.String(), struct {
+				AccountID string
+				Balance   bank.Money
+			}{*account, balance})
+		},
+	}
+}
+
+func newTransactionsCommand() *Command {
+	fs, state, dbPath, jsonOut := newFlagSet("transactions")
+	account := fs.String("account", "", "the account ID")
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "transactions: list an account's ledger entries")
+		fs.PrintDefaults()
+	}
+
+	return &Command{
+		Name:        "transactions",
+		Description: "list an account's ledger entries",
+		Flags:       fs,
+		CheckFlags:  func() error { return requireNonEmpty("account", *account) },
+		Do: func() error {
+			lib, closeLib, err := openLibrary(*dbPath, *state, nil)
+			if err != nil {
+				return err
+			}
+			defer closeLib()
+			amounts, err := lib.Transactions(*account)
+			if err != nil {
+				return err
+			}
+			if !*jsonOut {
+				for _, a := range amounts {
+					fmt.Println(a)
+				}
+				return nil
+			}
+			return printResult(true, "", struct {
+				AccountID    string
+				Transactions []bank.Money
+			}{*account, amounts})
+		},
+	}
+}
+
+func newTransferCommand() *Command {
+	fs, state, dbPath, jsonOut := newFlagSet("transfer")
+	from := fs.String("from", "", "the source account ID")
+	to := fs.String("to", "", "the destination account ID")
+	amount := fs.String("amount", "", `the amount, e.g. "12.34 USD"`)
+	memo := fs.String("memo", "", "a note recorded on both legs of the transfer")
+	fxRates := fs.String("fx-rates", "", `cross-currency rates, e.g. "USD->EUR=0.92,EUR->USD=1.09"; required if -from and -to hold different currencies`)
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "transfer: move money between two accounts")
+		fs.PrintDefaults()
+	}
+
+	return &Command{
+		Name:        "transfer",
+		Description: "move money between two accounts",
+		Flags:       fs,
+		CheckFlags: func() error {
+			if err := requireNonEmpty("from", *from); err != nil {
+				return err
+			}
+			if err := requireNonEmpty("to", *to); err != nil {
+				return err
+			}
+			return requireNonEmpty("amount", *amount)
+		},
+		Do: func() error {
+			money, err := bank.ParseMoney(*amount)
+			if err != nil {
+				return err
+			}
+			fx, err := parseFXRates(*fxRates)
+			if err != nil {
+				return err
+			}
+			lib, closeLib, err := openLibrary(*dbPath, *state, fx)
+			if err != nil {
+				return err
+			}
+			defer closeLib()
+			if err := lib.Transfer(*from, *to, money, *memo); err != nil {
+				return err
+			}
+			if err := persistLibrary(*dbPath, *state, lib); err != nil {
+				return err
+			}
+			human := fmt.Sprintf("transferred %s from %s to %s", money, *from, *to)
+			return printResult(*jsonOut, human, struct {
+				From, To string
+				Amount   bank.Money
+			}{*from, *to, money})
+		},
+	}
+}
+
+func newExportOFXCommand() *Command {
+	fs, state, dbPath, _ := newFlagSet("export-ofx")
+	account := fs.String("account", "", "the account ID")
+	from := fs.String("from", "", "start of the statement period (RFC3339); defaults to the zero time")
+	to := fs.String("to", "", "end of the statement period (RFC3339); defaults to now")
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "export-ofx: write an OFX statement for an account to stdout")
+		fs.PrintDefaults()
+	}
+
+	return &Command{
+		Name:        "export-ofx",
+		Description: "write an OFX statement for an account to stdout",
+		Flags:       fs,
+		CheckFlags:  func() error { return requireNonEmpty("account", *account) },
+		Do: func() error {
+			fromTime, err := parseTimeOrZero(*from)
+			if err != nil {
+				return fmt.Errorf("invalid -from timestamp %q: %w", *from, err)
+			}
+			toTime, err := parseTimeOrZero(*to)
+			if err != nil {
+				return fmt.Errorf("invalid -to timestamp %q: %w", *to, err)
+			}
+			if toTime.IsZero() {
+				toTime = time.Now()
+			}
+
+			lib, closeLib, err := openLibrary(*dbPath, *state, nil)
+			if err != nil {
+				return err
+			}
+			defer closeLib()
+			return lib.ExportOFX(*account, fromTime, toTime, os.Stdout)
+		},
+	}
+}
+
+func newLogsCommand() *Command {
+	fs, state, dbPath, jsonOut := newFlagSet("logs")
+	account := fs.String("account", "", "only show events for this account ID")
+	since := fs.String("since", "", "only show events at or after this RFC3339 timestamp")
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "logs: print the BankLibrary audit log")
+		fs.PrintDefaults()
+	}
+
+	return &Command{
+		Name:        "logs",
+		Description: "print the BankLibrary audit log",
+		Flags:       fs,
+		Do: func() error {
+			filter := bank.LogFilter{AccountID: *account}
+			if *since != "" {
+				t, err := time.Parse(time.RFC3339, *since)
+				if err != nil {
+					return fmt.Errorf("invalid -since timestamp %q: %w", *since, err)
+				}
+				filter.Since = t
+			}
+
+			lib, closeLib, err := openLibrary(*dbPath, *state, nil)
+			if err != nil {
+				return err
+			}
+			defer closeLib()
+			events, err := lib.Logs(filter)
+			if err != nil {
+				return err
+			}
+			if !*jsonOut {
+				if len(events) == 0 {
+					fmt.Println("no audit events")
+				}
+				for _, e := range events {
+					fmt.Printf("%s\t%s\t%s\t%s\t%s\t%s\n", e.Timestamp.Format(time.RFC3339), e.Action, e.AccountID, e.Amount, e.Result, e.ErrorCode)
+				}
+				return nil
+			}
+			return printResult(true, "", events)
+		},
+	}
+}
+
+func parseTimeOrZero(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339, s)
+}
+
+// newCommands returns every subcommand the CLI supports, keyed by name.
+func newCommands() map[string]*Command {
+	cmds := []*Command{
+		newCreateAccountCommand(),
+		newDepositCommand(),
+		newWithdrawCommand(),
+		newBalanceCommand(),
+		newTransactionsCommand(),
+		newTransferCommand(),
+		newExportOFXCommand(),
+		newLogsCommand(),
+	}
+	byName := make(map[string]*Command, len(cmds))
+	for _, c := range cmds {
+		byName[c.Name] = c
+	}
+	return byName
+}
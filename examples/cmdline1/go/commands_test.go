@@ -0,0 +1,210 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/drhodes/libspec/examples/bank-account/go/bank"
+)
+
+// TestStateFileRoundTrip exercises loadLibrary/saveLibrary the way each
+// subcommand does: one process invocation per call, reloading the
+// library from the --state file in between.
+func TestStateFileRoundTrip(t *testing.T) {
+	statePath := filepath.Join(t.TempDir(), "bank-state.json")
+
+	lib, err := loadLibrary(statePath, nil)
+	if err != nil {
+		t.Fatalf("loadLibrary (missing file) failed: %v", err)
+	}
+	id := lib.CreateAccount("Alice", "USD")
+	if err := saveLibrary(statePath, lib); err != nil {
+		t.Fatalf("saveLibrary failed: %v", err)
+	}
+
+	lib, err = loadLibrary(statePath, nil)
+	if err != nil {
+		t.Fatalf("loadLibrary failed: %v", err)
+	}
+	if err := lib.Deposit(id, bank.MustParseMoney("100 USD")); err != nil {
+		t.Fatalf("deposit failed: %v", err)
+	}
+	if err := saveLibrary(statePath, lib); err != nil {
+		t.Fatalf("saveLibrary failed: %v", err)
+	}
+
+	lib, err = loadLibrary(statePath, nil)
+	if err != nil {
+		t.Fatalf("loadLibrary failed: %v", err)
+	}
+	balance, err := lib.Balance(id)
+	if err != nil {
+		t.Fatalf("Balance failed: %v", err)
+	}
+	if balance != bank.MustParseMoney("100 USD") {
+		t.Errorf("expected balance 100 USD after reload, got %v", balance)
+	}
+}
+
+// TestLogsSeeAuditHistoryAcrossInvocations guards against the logs
+// subcommand losing its audit trail between CLI invocations, since each
+// subcommand is a separate process that only has the --state file to
+// go on.
+func TestLogsSeeAuditHistoryAcrossInvocations(t *testing.T) {
+	statePath := filepath.Join(t.TempDir(), "bank-state.json")
+
+	lib, err := loadLibrary(statePath, nil)
+	if err != nil {
+		t.Fatalf("loadLibrary (missing file) failed: %v", err)
+	}
+	id := lib.CreateAccount("Alice", "USD")
+	if err := saveLibrary(statePath, lib); err != nil {
+		t.Fatalf("saveLibrary failed: %v", err)
+	}
+
+	lib, err = loadLibrary(statePath, nil)
+	if err != nil {
+		t.Fatalf("loadLibrary failed: %v", err)
+	}
+	if err := lib.Deposit(id, bank.MustParseMoney("100 USD")); err != nil {
+		t.Fatalf("deposit failed: %v", err)
+	}
+	if err := saveLibrary(statePath, lib); err != nil {
+		t.Fatalf("saveLibrary failed: %v", err)
+	}
+
+	lib, err = loadLibrary(statePath, nil)
+	if err != nil {
+		t.Fatalf("loadLibrary failed: %v", err)
+	}
+	events, err := lib.Logs(bank.LogFilter{AccountID: id})
+	if err != nil {
+		t.Fatalf("Logs failed: %v", err)
+	}
+	if len(events) != 2 || events[0].Action != "CreateAccount" || events[1].Action != "Deposit" {
+		t.Errorf("expected CreateAccount and Deposit events from prior invocations, got %+v", events)
+	}
+}
+
+// TestParseFXRates guards against the -fx-rates flag on the transfer
+// subcommand silently misparsing, which would otherwise make
+// cross-currency transfers fail with a confusing "no FX rate" error
+// instead of the -fx-rates syntax error the user actually needs to see.
+func TestParseFXRates(t *testing.T) {
+	fx, err := parseFXRates("")
+	if err != nil || fx != nil {
+		t.Fatalf("expected empty -fx-rates to yield (nil, nil), got (%v, %v)", fx, err)
+	}
+
+	fx, err = parseFXRates("USD->EUR=0.92,EUR->USD=1.09")
+	if err != nil {
+		t.Fatalf("parseFXRates failed: %v", err)
+	}
+	rate, err := fx.Rate("USD", "EUR")
+	if err != nil || rate != 0.92 {
+		t.Errorf("expected USD->EUR rate 0.92, got %v (err %v)", rate, err)
+	}
+	rate, err = fx.Rate("EUR", "USD")
+	if err != nil || rate != 1.09 {
+		t.Errorf("expected EUR->USD rate 1.09, got %v (err %v)", rate, err)
+	}
+
+	if _, err := parseFXRates("USD->EUR"); err == nil {
+		t.Error("expected an error for a -fx-rates entry missing '='")
+	}
+	if _, err := parseFXRates("USD->EUR=not-a-number"); err == nil {
+		t.Error("expected an error for a -fx-rates entry with a non-numeric rate")
+	}
+}
+
+// TestOpenLibraryUsesFXRates exercises the fx parameter threaded
+// through openLibrary/loadLibrary end to end: without it, a
+// cross-currency transfer fails even though the library supports it;
+// with -fx-rates parsed and passed in, the transfer succeeds.
+func TestOpenLibraryUsesFXRates(t *testing.T) {
+	statePath := filepath.Join(t.TempDir(), "bank-state.json")
+
+	lib, closer, err := openLibrary("", statePath, nil)
+	if err != nil {
+		t.Fatalf("openLibrary failed: %v", err)
+	}
+	alice := lib.CreateAccount("Alice", "USD")
+	bob := lib.CreateAccount("Bob", "EUR")
+	if err := lib.Deposit(alice, bank.MustParseMoney("100 USD")); err != nil {
+		t.Fatalf("deposit failed: %v", err)
+	}
+	if err := lib.Transfer(alice, bob, bank.MustParseMoney("10 USD"), "no rate configured"); err == nil {
+		t.Fatal("expected a cross-currency transfer with no FX rate configured to fail")
+	}
+	if err := saveLibrary(statePath, lib); err != nil {
+		t.Fatalf("saveLibrary failed: %v", err)
+	}
+	closer()
+
+	fx, err := parseFXRates("USD->EUR=0.5")
+	if err != nil {
+		t.Fatalf("parseFXRates failed: %v", err)
+	}
+	lib, closer, err = openLibrary("", statePath, fx)
+	if err != nil {
+		t.Fatalf("openLibrary failed: %v", err)
+	}
+	defer closer()
+	if err := lib.Transfer(alice, bob, bank.MustParseMoney("10 USD"), "configured rate"); err != nil {
+		t.Fatalf("expected the -fx-rates-configured transfer to succeed, got %v", err)
+	}
+	bobBalance, err := lib.Balance(bob)
+	if err != nil {
+		t.Fatalf("Balance failed: %v", err)
+	}
+	if bobBalance != bank.MustParseMoney("5 EUR") {
+		t.Errorf("expected Bob's balance to be 5 EUR, got %v", bobBalance)
+	}
+}
+
+// TestLogsSeeAuditHistoryAcrossInvocationsDB is the -db counterpart to
+// TestLogsSeeAuditHistoryAcrossInvocations: it guards against the same
+// audit-history loss when the CLI is backed by SQLiteStore instead of
+// the JSON --state file.
+func TestLogsSeeAuditHistoryAcrossInvocationsDB(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "bank.db")
+
+	lib, closer, err := openLibrary(dbPath, "", nil)
+	if err != nil {
+		t.Fatalf("openLibrary failed: %v", err)
+	}
+	id := lib.CreateAccount("Alice", "USD")
+	if err := persistLibrary(dbPath, "", lib); err != nil {
+		t.Fatalf("persistLibrary failed: %v", err)
+	}
+	if err := closer(); err != nil {
+		t.Fatalf("closer failed: %v", err)
+	}
+
+	lib, closer, err = openLibrary(dbPath, "", nil)
+	if err != nil {
+		t.Fatalf("openLibrary failed: %v", err)
+	}
+	if err := lib.Deposit(id, bank.MustParseMoney("100 USD")); err != nil {
+		t.Fatalf("deposit failed: %v", err)
+	}
+	if err := persistLibrary(dbPath, "", lib); err != nil {
+		t.Fatalf("persistLibrary failed: %v", err)
+	}
+	if err := closer(); err != nil {
+		t.Fatalf("closer failed: %v", err)
+	}
+
+	lib, closer, err = openLibrary(dbPath, "", nil)
+	if err != nil {
+		t.Fatalf("openLibrary failed: %v", err)
+	}
+	defer closer()
+	events, err := lib.Logs(bank.LogFilter{AccountID: id})
+	if err != nil {
+		t.Fatalf("Logs failed: %v", err)
+	}
+	if len(events) != 2 || events[0].Action != "CreateAccount" || events[1].Action != "Deposit" {
+		t.Errorf("expected CreateAccount and Deposit events from prior invocations, got %+v", events)
+	}
+}
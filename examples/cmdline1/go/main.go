@@ -1,47 +1,53 @@
 package main
 
 import (
-	"flag"
 	"fmt"
 	"os"
 )
 
 func main() {
-	// Define flags
-	namePtr := flag.String("name", "", "Description: provide a name to greet")
-	shortNamePtr := flag.String("n", "", "Description: provide a name to greet (shorthand)")
-	
-	repeatPtr := flag.Int("repeat", 1, "Description: repeat word N times.")
-	shortRepeatPtr := flag.Int("r", 1, "Description: repeat word N times. (shorthand)")
-
-	// Custom help description
-	flag.Usage = func() {
-		fmt.Fprintf(os.Stderr, "Usage of %s:\n", os.Args[0])
-		fmt.Fprintf(os.Stderr, "  -h, --help      Description: show this help dialog\n")
-		fmt.Fprintf(os.Stderr, "  -n, --name      Description: provide a name to greet\n")
-		fmt.Fprintf(os.Stderr, "  -r, --repeat    Description: repeat word N times.\n")
+	commands := newCommands()
+
+	if len(os.Args) < 2 {
+		printUsage(commands)
+		os.Exit(1)
 	}
 
-	flag.Parse()
+	name := os.Args[1]
+	if name == "-h" || name == "--help" || name == "help" {
+		printUsage(commands)
+		return
+	}
 
-	// Consolidate shorthand and long-form flags
-	name := *namePtr
-	if name == "" {
-		name = *shortNamePtr
+	cmd, ok := commands[name]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n", name)
+		printUsage(commands)
+		os.Exit(1)
 	}
 
-	repeat := *repeatPtr
-	if repeat == 1 && *shortRepeatPtr != 1 {
-		repeat = *shortRepeatPtr
+	cmd.Flags.Parse(os.Args[2:])
+
+	if cmd.CheckFlags != nil {
+		if err := cmd.CheckFlags(); err != nil {
+			fmt.Fprintln(os.Stderr, "error:", err)
+			cmd.Flags.Usage()
+			os.Exit(1)
+		}
 	}
 
-	// Logic execution
-	greeting := "Hello"
-	if name != "" {
-		greeting = fmt.Sprintf("Hello, %s", name)
+	if err := cmd.Do(); err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		os.Exit(1)
 	}
+}
 
-	for i := 0; i < repeat; i++ {
-		fmt.Println(greeting)
+func printUsage(commands map[string]*Command) {
+	fmt.Fprintf(os.Stderr, "Usage: %s <command> [flags]\n\nCommands:\n", os.Args[0])
+	for _, name := range []string{"create-account", "deposit", "withdraw", "balance", "transactions", "transfer", "export-ofx", "logs"} {
+		if cmd, ok := commands[name]; ok {
+			fmt.Fprintf(os.Stderr, "  %-16s %s\n", cmd.Name, cmd.Description)
+		}
 	}
+	fmt.Fprintf(os.Stderr, "\nEvery command accepts -state (the JSON state file, default bank-state.json), -db (a SQLite database file for durable storage instead), and -json (machine-readable output).\nRun \"%s <command> -h\" for a command's flags.\n", os.Args[0])
 }